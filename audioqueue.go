@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// clauseResult is the outcome of synthesizing one queued clause.
+type clauseResult struct {
+	stream io.ReadCloser
+	format AudioFormat
+	err    error
+}
+
+// AudioQueue plays an ordered sequence of text clauses back-to-back through
+// a single Player. Clauses start synthesizing as soon as they're enqueued
+// (so TTS for clause N+1 overlaps playback of clause N), but a single
+// playback goroutine enforces strict in-order, non-overlapping playback.
+type AudioQueue struct {
+	tts     SpeechSynthesizer
+	player  *Player
+	futures chan chan clauseResult
+	done    chan struct{}
+}
+
+// NewAudioQueue creates an AudioQueue for one conversational turn.
+func NewAudioQueue(tts SpeechSynthesizer, player *Player) *AudioQueue {
+	return &AudioQueue{
+		tts:     tts,
+		player:  player,
+		futures: make(chan chan clauseResult, 16),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start launches the single playback goroutine. Call Enqueue as clauses
+// become available, Close once no more are coming, and wait on Done for
+// playback to finish draining.
+func (q *AudioQueue) Start(ctx context.Context) {
+	go func() {
+		defer close(q.done)
+		for future := range q.futures {
+			result := <-future
+			if result.err != nil {
+				fmt.Printf("\nfailed to synthesize clause: %v\n", result.err)
+				continue
+			}
+			if err := q.player.Play(ctx, result.stream, result.format); err != nil {
+				fmt.Printf("\nfailed to play clause: %v\n", err)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+}
+
+// Enqueue reserves clause's place in playback order and begins synthesizing
+// it immediately in the background.
+func (q *AudioQueue) Enqueue(ctx context.Context, clause string) {
+	future := make(chan clauseResult, 1)
+	q.futures <- future
+
+	go func() {
+		stream, format, err := q.tts.Synthesize(ctx, clause)
+		future <- clauseResult{stream: stream, format: format, err: err}
+	}()
+}
+
+// Close signals that no more clauses will be enqueued; already-queued
+// clauses still play out.
+func (q *AudioQueue) Close() {
+	close(q.futures)
+}
+
+// Done closes once every enqueued clause has played (or ctx was cancelled).
+func (q *AudioQueue) Done() <-chan struct{} {
+	return q.done
+}
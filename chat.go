@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Canonical chat roles shared across providers.
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// ChatMessage is a provider-agnostic message in a conversation. ToolCalls and
+// ToolCallID only apply to the modern tools/tool_calls protocol: an assistant
+// message that invoked a tool carries ToolCalls (so providers that need the
+// call echoed back, like OpenAI, can replay it), and the RoleTool message
+// answering it carries ToolCallID so the provider can match the two up.
+type ChatMessage struct {
+	Role       string
+	Content    string
+	ToolCalls  []FunctionCall
+	ToolCallID string
+}
+
+// FunctionSpec describes a callable function the model may invoke, in the
+// JSON-schema shape OpenAI's function calling (and OpenAI-compatible local
+// servers) use.
+type FunctionSpec struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{}
+}
+
+// FunctionCall is an in-progress or completed function invocation requested
+// by the model; Name and ID typically arrive in the first delta of a call,
+// Arguments accumulates across every subsequent delta until the stream ends.
+// Index distinguishes multiple tool calls the model issues in the same turn
+// (providers like OpenAI support parallel tool_calls); it's only meaningful
+// on a ChatDelta and ignored once a call is persisted into
+// ChatMessage.ToolCalls.
+type FunctionCall struct {
+	Index     int
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// ChatDelta is one incremental piece of a streamed chat completion. Err is
+// set on the final delta sent before the channel closes if the stream ended
+// abnormally (e.g. the connection dropped mid-response); callers should
+// check it once the range over the channel finishes.
+type ChatDelta struct {
+	Content      string
+	FunctionCall *FunctionCall
+	Err          error
+}
+
+// ChatModel streams a chat completion for a system prompt plus message
+// history, optionally offering the model a set of callable functions.
+// Implementations close the returned channel once the stream ends.
+type ChatModel interface {
+	StreamChat(ctx context.Context, system string, history []ChatMessage, functions []FunctionSpec) (<-chan ChatDelta, error)
+
+	// SupportsTools reports whether this provider can be sent function
+	// specs at all. Callers must not pass a non-empty functions slice to
+	// StreamChat when this returns false.
+	SupportsTools() bool
+}
+
+// NewChatModel builds the ChatModel selected by the LLM_PROVIDER env var
+// (openai|anthropic|openai_compatible), defaulting to openai.
+func NewChatModel(name string, config *Config) (ChatModel, error) {
+	switch name {
+	case "", "openai":
+		if config.OpenAIKey == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY is required for the openai LLM provider")
+		}
+		return newOpenAIChatModel(config.OpenAIKey, ""), nil
+	case "openai_compatible":
+		// Points at a local OpenAI-compatible server, e.g. llama.cpp's
+		// server or Ollama's /v1 endpoint, for fully offline operation.
+		base := getEnvWithDefault("OPENAI_API_BASE", "http://localhost:8080/v1")
+		return newOpenAIChatModel(config.OpenAIKey, base), nil
+	case "anthropic":
+		if config.AnthropicKey == "" {
+			return nil, fmt.Errorf("ANTHROPIC_API_KEY is required for the anthropic LLM provider")
+		}
+		return newAnthropicChatModel(config.AnthropicKey), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER %q", name)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	anthropicAPIURL     = "https://api.anthropic.com/v1/messages"
+	anthropicAPIVersion = "2023-06-01"
+	anthropicModel      = "claude-3-5-sonnet-20241022"
+)
+
+// anthropicChatModel streams chat completions from the Anthropic Messages
+// API. It's a small hand-rolled client rather than the official SDK: the
+// protocol is a plain HTTP+SSE request/response and the official SDK's
+// minimum Go version is newer than what this project targets.
+//
+// Note: Anthropic's function-calling shape differs from OpenAI's and isn't
+// translated here, so SupportsTools reports false; callers must not pass
+// tool specs to StreamChat.
+type anthropicChatModel struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newAnthropicChatModel(apiKey string) *anthropicChatModel {
+	return &anthropicChatModel{apiKey: apiKey, httpClient: &http.Client{}}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system"`
+	Messages  []anthropicMessage `json:"messages"`
+	MaxTokens int                `json:"max_tokens"`
+	Stream    bool               `json:"stream"`
+}
+
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+}
+
+// SupportsTools reports that the Anthropic provider can't be sent tool
+// specs: its tool_use block shape differs from OpenAI's and isn't
+// translated here.
+func (m *anthropicChatModel) SupportsTools() bool { return false }
+
+func (m *anthropicChatModel) StreamChat(ctx context.Context, system string, history []ChatMessage, functions []FunctionSpec) (<-chan ChatDelta, error) {
+	if len(functions) > 0 {
+		return nil, fmt.Errorf("anthropic LLM provider does not support function/tool calling (%d tool(s) registered); check SupportsTools before calling", len(functions))
+	}
+
+	messages := make([]anthropicMessage, 0, len(history))
+	for _, msg := range history {
+		// Anthropic only accepts user/assistant roles in the messages array.
+		if msg.Role != RoleUser && msg.Role != RoleAssistant {
+			continue
+		}
+		messages = append(messages, anthropicMessage{Role: msg.Role, Content: msg.Content})
+	}
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:     anthropicModel,
+		System:    system,
+		Messages:  messages,
+		MaxTokens: 1024,
+		Stream:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode anthropic request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, anthropicAPIURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build anthropic request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", m.apiKey)
+	req.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call anthropic: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("anthropic returned status %d", resp.StatusCode)
+	}
+
+	deltas := make(chan ChatDelta)
+	go func() {
+		defer resp.Body.Close()
+		defer close(deltas)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			var evt anthropicStreamEvent
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &evt); err != nil {
+				continue
+			}
+			if evt.Type == "content_block_delta" && evt.Delta.Type == "text_delta" {
+				deltas <- ChatDelta{Content: evt.Delta.Text}
+			}
+		}
+	}()
+
+	return deltas, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// openAIChatModel streams chat completions from the OpenAI API, or from any
+// OpenAI-compatible endpoint (llama.cpp, Ollama) when baseURL is set.
+type openAIChatModel struct {
+	client *openai.Client
+}
+
+func newOpenAIChatModel(apiKey, baseURL string) *openAIChatModel {
+	if baseURL == "" {
+		return &openAIChatModel{client: openai.NewClient(apiKey)}
+	}
+
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.BaseURL = baseURL
+	return &openAIChatModel{client: openai.NewClientWithConfig(cfg)}
+}
+
+// SupportsTools reports that OpenAI (and OpenAI-compatible) endpoints can be
+// sent tool/function specs.
+func (m *openAIChatModel) SupportsTools() bool { return true }
+
+func (m *openAIChatModel) StreamChat(ctx context.Context, system string, history []ChatMessage, functions []FunctionSpec) (<-chan ChatDelta, error) {
+	messages := make([]openai.ChatCompletionMessage, 0, len(history)+1)
+	messages = append(messages, openai.ChatCompletionMessage{Role: RoleSystem, Content: system})
+	for _, msg := range history {
+		out := openai.ChatCompletionMessage{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			ToolCallID: msg.ToolCallID,
+		}
+		for _, tc := range msg.ToolCalls {
+			out.ToolCalls = append(out.ToolCalls, openai.ToolCall{
+				ID:   tc.ID,
+				Type: openai.ToolTypeFunction,
+				Function: openai.FunctionCall{
+					Name:      tc.Name,
+					Arguments: tc.Arguments,
+				},
+			})
+		}
+		messages = append(messages, out)
+	}
+
+	// Use the modern tools/tool_calls protocol (rather than the deprecated
+	// singular function_call) since it's the only shape that carries a
+	// ToolCall ID, which role="tool" result messages must reference.
+	var tools []openai.Tool
+	for _, f := range functions {
+		tools = append(tools, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionDefinition{
+				Name:        f.Name,
+				Description: f.Description,
+				Parameters:  f.Parameters,
+			},
+		})
+	}
+
+	stream, err := m.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:    openai.GPT4,
+		Messages: messages,
+		Stream:   true,
+		Tools:    tools,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat completion: %v", err)
+	}
+
+	deltas := make(chan ChatDelta)
+	go func() {
+		defer stream.Close()
+		defer close(deltas)
+
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				deltas <- ChatDelta{Err: fmt.Errorf("stream error: %v", err)}
+				return
+			}
+			if len(resp.Choices) == 0 {
+				continue
+			}
+
+			delta := resp.Choices[0].Delta
+			if delta.Content != "" {
+				deltas <- ChatDelta{Content: delta.Content}
+			}
+			// The model can request several tools in parallel; each
+			// ToolCalls entry carries its own Index so the caller can
+			// accumulate them independently instead of only ever seeing
+			// the first one.
+			for _, tc := range delta.ToolCalls {
+				index := 0
+				if tc.Index != nil {
+					index = *tc.Index
+				}
+				deltas <- ChatDelta{FunctionCall: &FunctionCall{
+					Index:     index,
+					ID:        tc.ID,
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
+				}}
+			}
+		}
+	}()
+
+	return deltas, nil
+}
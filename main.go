@@ -6,31 +6,23 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
 	"os"
-	"os/exec"
-	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-audio/audio"
 	"github.com/go-audio/wav"
 	"github.com/gordonklaus/portaudio"
-	"github.com/haguro/elevenlabs-go"
 	"github.com/joho/godotenv"
-	"github.com/sashabaranov/go-openai"
 )
 
 const (
 	bufferDuration        = 0.5              // seconds
 	longTermAlpha         = 0.995            // Slower adaptation for background noise
 	currentNoiseAlpha     = 0.920            // Faster adaptation for current level
-	voiceStartThreshold   = 2.5              // Multiplier over noise level for voice detection
-	voiceEndThreshold     = 1.5              // Multiplier over noise level for voice end
-	minNoiseFloor         = 100.0            // Minimum noise floor to prevent false triggers in silence
 	adaptationPeriod      = 50               // Number of frames to adapt to environment
-	maxPlaybackLevel      = 10000.0          // Level above which we consider the audio to be from playback
 	voiceDetectionTimeout = 5 * time.Second  // Timeout duration for voice detection
 	timeoutPromptMessage  = "are you there?" // Message to play when timeout occurs
 	debugMode             = true             // Enable debug logging
@@ -53,12 +45,17 @@ const (
 // Config holds the application configuration
 type Config struct {
 	OpenAIKey         string
+	AnthropicKey      string
 	ElevenLabsKey     string
 	ElevenLabsVoiceID string
 	ElevenLabsModelID string
 	SampleRate        int
 	FramesPerBuffer   int
 	Channels          int
+	STTProvider       string // whisper|openai_realtime|google_streaming|whisper_cpp
+	LLMProvider       string // openai|anthropic|openai_compatible
+	TTSProvider       string // elevenlabs|openai|local_piper
+	WebSearchProvider string // duckduckgo|none
 }
 
 // LoadConfig loads configuration from environment variables
@@ -71,20 +68,17 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		OpenAIKey:         os.Getenv("OPENAI_API_KEY"),
+		AnthropicKey:      os.Getenv("ANTHROPIC_API_KEY"),
 		ElevenLabsKey:     os.Getenv("ELEVENLABS_API_KEY"),
 		ElevenLabsVoiceID: os.Getenv("ELEVENLABS_VOICE_ID"),
 		ElevenLabsModelID: getEnvWithDefault("ELEVENLABS_MODEL_ID", "eleven_monolingual_v1"),
 		SampleRate:        getEnvAsIntWithDefault("SAMPLE_RATE", 16000),
 		FramesPerBuffer:   getEnvAsIntWithDefault("FRAMES_PER_BUFFER", 512),
 		Channels:          getEnvAsIntWithDefault("CHANNELS", 1),
-	}
-
-	// Validate required configuration
-	if config.OpenAIKey == "" {
-		return nil, fmt.Errorf("OPENAI_API_KEY is required")
-	}
-	if config.ElevenLabsKey == "" {
-		return nil, fmt.Errorf("ELEVENLABS_API_KEY is required")
+		STTProvider:       getEnvWithDefault("STT_PROVIDER", "whisper"),
+		LLMProvider:       getEnvWithDefault("LLM_PROVIDER", "openai"),
+		TTSProvider:       getEnvWithDefault("TTS_PROVIDER", "elevenlabs"),
+		WebSearchProvider: getEnvWithDefault("WEB_SEARCH_PROVIDER", "duckduckgo"),
 	}
 
 	return config, nil
@@ -109,48 +103,66 @@ func getEnvAsIntWithDefault(key string, defaultValue int) int {
 type AudioProcessor struct {
 	stream              *portaudio.Stream
 	audioBuffer         [][]int16
-	frames              [][]int16
 	longTermNoise       float64
 	currentNoise        float64
 	voiceDetected       bool
-	ambientNoise        float64
-	openAIClient        *openai.Client
-	elevenLabsClient    *elevenlabs.Client
-	systemPrompt        openai.ChatCompletionMessage
-	conversationHistory []openai.ChatCompletionMessage
+	vad                 *VoiceDetector
+	transcriber         Transcriber
+	llm                 ChatModel
+	tts                 SpeechSynthesizer
+	player              *Player
+	webSearcher         WebSearcher
+	tools               map[string]Tool
+	conversationHistory []ChatMessage
 	config              *Config
 	inputBuffer         []float32
 	done                chan bool
-	silenceFrames       int       // Count consecutive silence frames
 	frameCount          int       // Count frames for initial adaptation
 	startTime           time.Time // Track when we started listening
 	promptPlayed        bool      // Track if we've played the "are you there?" prompt
 	isPlaying           bool      // Track if we're currently playing audio
+	listening           bool      // Track if the current turn is still accepting mic input
+	pendingExit         bool      // Set by the detectGoodbye tool once the model calls it
 }
 
-func NewAudioProcessor(config *Config) (*AudioProcessor, error) {
+// NewAudioProcessor wires up an AudioProcessor around the given
+// STT/LLM/TTS abstractions, so the caller picks concrete providers (and
+// main only ever talks to the interfaces, which also makes fakes possible
+// for tests).
+func NewAudioProcessor(config *Config, llm ChatModel, tts SpeechSynthesizer) (*AudioProcessor, error) {
 	err := portaudio.Initialize()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize PortAudio: %v", err)
 	}
 
 	bufferSize := int(float64(config.SampleRate/config.FramesPerBuffer) * bufferDuration)
-	ctx := context.Background()
-
-	return &AudioProcessor{
-		audioBuffer:      make([][]int16, 0, bufferSize),
-		frames:           make([][]int16, 0),
-		openAIClient:     openai.NewClient(config.OpenAIKey),
-		elevenLabsClient: elevenlabs.NewClient(ctx, config.ElevenLabsKey, 30*time.Second),
-		config:           config,
-		inputBuffer:      make([]float32, config.FramesPerBuffer),
-		done:             make(chan bool),
-		systemPrompt: openai.ChatCompletionMessage{
-			Role:    "system",
-			Content: systemPrompt,
-		},
-		conversationHistory: make([]openai.ChatCompletionMessage, 0),
-	}, nil
+
+	vad := NewVoiceDetector(config.SampleRate, config.FramesPerBuffer)
+	vad.OnSpeechStart = func() {
+		fmt.Println("\nVoice activity detected!")
+	}
+
+	ap := &AudioProcessor{
+		audioBuffer:         make([][]int16, 0, bufferSize),
+		vad:                 vad,
+		llm:                 llm,
+		tts:                 tts,
+		player:              NewPlayer(),
+		webSearcher:         NewWebSearcher(config.WebSearchProvider),
+		config:              config,
+		inputBuffer:         make([]float32, config.FramesPerBuffer),
+		done:                make(chan bool),
+		conversationHistory: make([]ChatMessage, 0),
+	}
+	ap.registerBuiltinTools()
+
+	transcriber, err := NewTranscriber(config.STTProvider, ap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transcriber: %v", err)
+	}
+	ap.transcriber = transcriber
+
+	return ap, nil
 }
 
 func (ap *AudioProcessor) getLevels(data []int16) (float64, float64, float64) {
@@ -173,15 +185,18 @@ func (ap *AudioProcessor) audioCallback(in []float32) {
 		buffer[i] = int16(sample * 32767.0)
 	}
 
-	// Calculate current audio level
-	var sum float64
-	for _, sample := range buffer {
-		sum += math.Abs(float64(sample))
+	// While Kate is speaking, the mic stream is kept open specifically so
+	// this runs: feed the VAD just enough to notice a barge-in (which
+	// triggers ap.vad.Cancel()) without touching the listening-turn state
+	// machine (timeout prompt, transcription) below.
+	if ap.isPlaying {
+		ap.vad.ProcessFrame(buffer, true)
+		return
 	}
-	currentLevel := sum / float64(len(buffer))
 
-	// If we're playing audio and the level is very high, likely it's our own playback
-	if ap.isPlaying && currentLevel > maxPlaybackLevel {
+	// Between turns (after the user's utterance ends and before the next
+	// startRecording resets state) frames should simply be dropped.
+	if !ap.listening {
 		return
 	}
 
@@ -202,6 +217,7 @@ func (ap *AudioProcessor) audioCallback(in []float32) {
 			return
 		} else if elapsed > voiceDetectionTimeout && ap.promptPlayed {
 			fmt.Println("\nNo response received, stopping...")
+			ap.listening = false
 			ap.done <- true
 			return
 		}
@@ -210,10 +226,6 @@ func (ap *AudioProcessor) audioCallback(in []float32) {
 	pegel, longTermNoise, currentNoise := ap.getLevels(buffer)
 	ap.audioBuffer = append(ap.audioBuffer, buffer)
 
-	// Calculate adaptive thresholds
-	startThreshold := math.Max(longTermNoise*voiceStartThreshold, minNoiseFloor)
-	endThreshold := math.Max(ap.ambientNoise*voiceEndThreshold, minNoiseFloor/2)
-
 	// Debug logging with more detail
 	if debugAudioLevel {
 		maxSample := float32(0)
@@ -223,21 +235,20 @@ func (ap *AudioProcessor) audioCallback(in []float32) {
 			}
 		}
 
-		fmt.Printf("\rAudio Levels - Current: %.2f, Noise: %.2f, Start Threshold: %.2f, End Threshold: %.2f, Peak: %.2f   ",
-			currentNoise, longTermNoise, startThreshold, endThreshold, pegel)
+		fmt.Printf("\rAudio Levels - Current: %.2f, Noise: %.2f, Peak: %.2f   ",
+			currentNoise, longTermNoise, pegel)
 	}
 
+	speaking := ap.vad.ProcessFrame(buffer, false)
+
 	if ap.voiceDetected {
-		ap.frames = append(ap.frames, buffer)
-		if currentNoise < endThreshold {
-			// Require multiple frames below threshold to avoid cutting off during brief pauses
-			ap.silenceFrames++
-			if ap.silenceFrames > 10 { // About 200ms of silence
-				ap.done <- true
-				return
-			}
-		} else {
-			ap.silenceFrames = 0
+		if err := ap.transcriber.WriteFrame(buffer); err != nil {
+			fmt.Printf("\ntranscriber write error: %v\n", err)
+		}
+		if !speaking {
+			ap.listening = false
+			ap.done <- true
+			return
 		}
 	} else {
 		// Wait for initial adaptation period
@@ -246,12 +257,15 @@ func (ap *AudioProcessor) audioCallback(in []float32) {
 			return
 		}
 
-		if currentNoise > startThreshold {
+		if speaking {
 			ap.voiceDetected = true
-			fmt.Println("\nVoice activity detected!")
-			ap.ambientNoise = longTermNoise
-			ap.frames = append(ap.frames, ap.audioBuffer...)
-			ap.silenceFrames = 0
+			// Feed the pre-roll buffered before onset so the transcript
+			// isn't missing the first syllables.
+			for _, frame := range ap.audioBuffer {
+				if err := ap.transcriber.WriteFrame(frame); err != nil {
+					fmt.Printf("\ntranscriber write error: %v\n", err)
+				}
+			}
 		}
 	}
 }
@@ -260,14 +274,17 @@ func (ap *AudioProcessor) startRecording() error {
 	// Reset state
 	ap.voiceDetected = false
 	ap.audioBuffer = ap.audioBuffer[:0]
-	ap.frames = ap.frames[:0]
 	ap.longTermNoise = 0
 	ap.currentNoise = 0
-	ap.ambientNoise = 0
-	ap.silenceFrames = 0
+	ap.vad.Reset()
 	ap.frameCount = 0
 	ap.startTime = time.Now()
 	ap.promptPlayed = false // Reset the prompt flag
+	ap.listening = true
+
+	if err := ap.transcriber.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start transcriber: %v", err)
+	}
 
 	fmt.Println("Opening audio stream...")
 
@@ -324,9 +341,11 @@ func (ap *AudioProcessor) processAudio() error {
 	return nil
 }
 
-func (ap *AudioProcessor) saveWAV(filename string) error {
+// writeFramesToWAV flattens frames and writes them to filename as a 16-bit
+// PCM WAV file at config's sample rate/channel count.
+func writeFramesToWAV(frames [][]int16, config *Config, filename string) error {
 	var flatFrames []int16
-	for _, frame := range ap.frames {
+	for _, frame := range frames {
 		flatFrames = append(flatFrames, frame...)
 	}
 
@@ -344,13 +363,13 @@ func (ap *AudioProcessor) saveWAV(filename string) error {
 	}
 	defer f.Close()
 
-	enc := wav.NewEncoder(f, ap.config.SampleRate, 16, ap.config.Channels, 1)
+	enc := wav.NewEncoder(f, config.SampleRate, 16, config.Channels, 1)
 	defer enc.Close()
 
 	audioBuf := &audio.IntBuffer{
 		Format: &audio.Format{
-			NumChannels: ap.config.Channels,
-			SampleRate:  ap.config.SampleRate,
+			NumChannels: config.Channels,
+			SampleRate:  config.SampleRate,
 		},
 		Data:           make([]int, len(flatFrames)),
 		SourceBitDepth: 16,
@@ -368,9 +387,14 @@ func (ap *AudioProcessor) saveWAV(filename string) error {
 	return nil
 }
 
+// generateResponse streams the model's reply, speaking completed clauses as
+// they arrive. If the model calls a registered tool, the result is appended
+// to the conversation as a tool message and the chat completion is
+// re-invoked, looping until a turn produces plain content with no further
+// call. shouldExit reflects whatever the detectGoodbye tool last set.
 func (ap *AudioProcessor) generateResponse(userText string) (string, error, bool) {
-	ap.conversationHistory = append(ap.conversationHistory, openai.ChatCompletionMessage{
-		Role:    "user",
+	ap.conversationHistory = append(ap.conversationHistory, ChatMessage{
+		Role:    RoleUser,
 		Content: userText,
 	})
 
@@ -378,113 +402,179 @@ func (ap *AudioProcessor) generateResponse(userText string) (string, error, bool
 		ap.conversationHistory = ap.conversationHistory[len(ap.conversationHistory)-10:]
 	}
 
-	messages := append([]openai.ChatCompletionMessage{ap.systemPrompt}, ap.conversationHistory...)
-
-	// Add function calling to detect goodbyes
-	functionCall := openai.FunctionDefinition{
-		Name:        "detectGoodbye",
-		Description: "Detect if the user wants to end the conversation",
-		Parameters: map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"shouldExit": map[string]interface{}{
-					"type":        "boolean",
-					"description": "Set to true if the user's message indicates they want to end the conversation",
-				},
-			},
-			"required": []string{"shouldExit"},
-		},
-	}
+	ap.pendingExit = false
 
-	stream, err := ap.openAIClient.CreateChatCompletionStream(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model:     openai.GPT4,
-			Messages:  messages,
-			Stream:    true,
-			Functions: []openai.FunctionDefinition{functionCall},
-		},
-	)
-	if err != nil {
-		return "", fmt.Errorf("failed to create chat completion: %v", err), false
+	// Providers that can't honor tool specs (see ChatModel.SupportsTools)
+	// must never be sent them - StreamChat rejects a non-empty functions
+	// slice outright rather than silently dropping it.
+	var functions []FunctionSpec
+	if ap.llm.SupportsTools() {
+		functions = ap.toolFunctionSpecs()
 	}
-	defer stream.Close()
+
+	// isPlaying gates both the mic callback's barge-in check (audioCallback)
+	// and the watcher below; set it for the whole turn since clauses start
+	// playing as soon as they're synthesized, not just once the full
+	// response is ready.
+	ap.isPlaying = true
+	defer func() { ap.isPlaying = false }()
+
+	ctx, cancel := ap.bargeInContext()
+	defer cancel()
+
+	queue := NewAudioQueue(ap.tts, ap.player)
+	queue.Start(ctx)
 
 	var fullResponse string
-	var shouldExit bool
-	var functionArgs string
 
 	for {
-		response, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
+		deltas, err := ap.llm.StreamChat(ctx, systemPrompt, ap.conversationHistory, functions)
 		if err != nil {
-			return "", fmt.Errorf("stream error: %v", err), false
+			queue.Close()
+			<-queue.Done()
+			return "", fmt.Errorf("failed to create chat completion: %v", err), ap.pendingExit
 		}
 
-		if len(response.Choices) > 0 {
-			if response.Choices[0].Delta.Content != "" {
-				chunk := response.Choices[0].Delta.Content
-				fmt.Print(chunk)
-				fullResponse += chunk
+		var turnContent, pending string
+		var streamErr error
+		calls := map[int]*FunctionCall{}
+		var callOrder []int
+
+		for delta := range deltas {
+			if delta.Err != nil {
+				streamErr = delta.Err
+				continue
+			}
+			if delta.Content != "" {
+				fmt.Print(delta.Content)
+				turnContent += delta.Content
+				pending += delta.Content
+
+				var clauses []string
+				clauses, pending = splitClauses(pending)
+				for _, clause := range clauses {
+					queue.Enqueue(ctx, clause)
+				}
 			}
+			if delta.FunctionCall != nil {
+				idx := delta.FunctionCall.Index
+				call, ok := calls[idx]
+				if !ok {
+					call = &FunctionCall{Index: idx}
+					calls[idx] = call
+					callOrder = append(callOrder, idx)
+				}
+				if delta.FunctionCall.ID != "" {
+					call.ID = delta.FunctionCall.ID
+				}
+				if delta.FunctionCall.Name != "" {
+					call.Name = delta.FunctionCall.Name
+				}
+				call.Arguments += delta.FunctionCall.Arguments
+			}
+		}
+
+		if streamErr != nil {
+			queue.Close()
+			<-queue.Done()
+			return "", fmt.Errorf("chat completion stream failed: %v", streamErr), ap.pendingExit
+		}
 
-			// Accumulate function call arguments
-			if response.Choices[0].Delta.FunctionCall != nil {
-				functionArgs += response.Choices[0].Delta.FunctionCall.Arguments
+		if remainder := strings.TrimSpace(pending); remainder != "" {
+			queue.Enqueue(ctx, remainder)
+		}
+		fullResponse += turnContent
+
+		if len(callOrder) == 0 {
+			if turnContent != "" {
+				ap.conversationHistory = append(ap.conversationHistory, ChatMessage{
+					Role:    RoleAssistant,
+					Content: turnContent,
+				})
+			}
+			// Providers without tool support never get to call
+			// detectGoodbye, so fall back to reading the model's own
+			// words for an end-of-conversation cue.
+			if !ap.llm.SupportsTools() && looksLikeGoodbye(turnContent) {
+				ap.pendingExit = true
 			}
+			break
+		}
+
+		// The model can request several tools in the same turn (OpenAI's
+		// parallel tool_calls); gather them all by Index before replying so
+		// none are silently dropped.
+		toolCalls := make([]FunctionCall, 0, len(callOrder))
+		for _, idx := range callOrder {
+			toolCalls = append(toolCalls, *calls[idx])
 		}
-	}
 
-	// Parse function call result after stream ends
-	if functionArgs != "" {
-		var result struct {
-			ShouldExit bool `json:"shouldExit"`
+		if debugMode {
+			for _, call := range toolCalls {
+				fmt.Printf("\nTool call: %s(%s)\n", call.Name, call.Arguments)
+			}
 		}
-		if err := json.Unmarshal([]byte(functionArgs), &result); err == nil {
-			shouldExit = result.ShouldExit
-			if debugMode {
-				fmt.Printf("\nFunction call result: shouldExit = %v\n", shouldExit)
+
+		// The assistant message carrying the tool calls must be in history
+		// (with ToolCalls echoed back) before the matching RoleTool results,
+		// or providers that validate the protocol will reject the
+		// follow-up request.
+		ap.conversationHistory = append(ap.conversationHistory, ChatMessage{
+			Role:      RoleAssistant,
+			Content:   turnContent,
+			ToolCalls: toolCalls,
+		})
+
+		for _, call := range toolCalls {
+			result, err := ap.callTool(ctx, call.Name, json.RawMessage(call.Arguments))
+			if err != nil {
+				queue.Close()
+				<-queue.Done()
+				return "", fmt.Errorf("failed to call tool %s: %v", call.Name, err), ap.pendingExit
 			}
+
+			ap.conversationHistory = append(ap.conversationHistory, ChatMessage{
+				Role:       RoleTool,
+				Content:    result,
+				ToolCallID: call.ID,
+			})
 		}
 	}
 
+	queue.Close()
+	<-queue.Done()
+
 	// log full response in debug mode
 	if debugMode {
 		fmt.Printf("\nFull LLM response: %s\n", fullResponse)
 	}
 
-	ap.conversationHistory = append(ap.conversationHistory, openai.ChatCompletionMessage{
-		Role:    "assistant",
-		Content: fullResponse,
-	})
-
-	return fullResponse, nil, shouldExit
+	return fullResponse, nil, ap.pendingExit
 }
 
-func (ap *AudioProcessor) transcribeAudio(filename string) (string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return "", fmt.Errorf("failed to open audio file: %v", err)
-	}
-	defer file.Close()
-
-	req := openai.AudioRequest{
-		Model:    openai.Whisper1,
-		Reader:   file,
-		FilePath: filename,
-		Language: "en",
-	}
-
-	resp, err := ap.openAIClient.CreateTranscription(context.Background(), req)
-	if err != nil {
-		return "", fmt.Errorf("failed to transcribe audio: %v", err)
-	}
-
-	return resp.Text, nil
+// bargeInContext returns a context that's cancelled the moment the VAD
+// reports a barge-in (speech starting while ap.isPlaying is true), aborting
+// whatever playback or generation is in flight. Callers must invoke the
+// returned cancel func once done to stop the watcher goroutine; it's safe to
+// call even after a barge-in already fired.
+func (ap *AudioProcessor) bargeInContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-ap.vad.Cancel():
+			fmt.Println("\nBarge-in detected, stopping playback...")
+			ap.player.Stop()
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
 }
 
+// synthesizeSpeech streams text to the configured TTS provider and plays the
+// resulting audio directly through the Player, without round-tripping
+// through a response.mp3 file. Playback aborts immediately if the VAD
+// reports a barge-in while we're speaking.
 func (ap *AudioProcessor) synthesizeSpeech(text string, config *Config) error {
 	// Set isPlaying to true before playing audio
 	ap.isPlaying = true
@@ -494,70 +584,15 @@ func (ap *AudioProcessor) synthesizeSpeech(text string, config *Config) error {
 		time.Sleep(time.Millisecond * 100)
 	}()
 
-	// Get available voices if voice ID is not specified
-	var voiceID string
-	if config.ElevenLabsVoiceID != "" {
-		voiceID = config.ElevenLabsVoiceID
-	} else {
-		voices, err := ap.elevenLabsClient.GetVoices()
-		if err != nil {
-			return fmt.Errorf("failed to get voices: %v", err)
-		}
-
-		// Find Nicole voice or use the first available voice
-		for _, voice := range voices {
-			if voice.Name == "Nicole" {
-				voiceID = voice.VoiceId
-				break
-			}
-		}
-		if voiceID == "" && len(voices) > 0 {
-			voiceID = voices[0].VoiceId
-		}
-	}
-
-	// Create temporary file for the response
-	tempFile := "response.mp3"
-	out, err := os.Create(tempFile)
-	if err != nil {
-		return fmt.Errorf("failed to create temporary file: %v", err)
-	}
-	defer func() {
-		out.Close()
-		os.Remove(tempFile)
-	}()
-
-	// Create text-to-speech request
-	ttsReq := elevenlabs.TextToSpeechRequest{
-		Text:    text,
-		ModelID: config.ElevenLabsModelID,
-	}
-
-	// Generate speech
-	err = ap.elevenLabsClient.TextToSpeechStream(out, voiceID, ttsReq)
+	audioStream, format, err := ap.tts.Synthesize(context.Background(), text)
 	if err != nil {
 		return fmt.Errorf("failed to generate speech: %v", err)
 	}
 
-	// For simplicity, we'll use the system's default audio player
-	return ap.playAudioFile(tempFile)
-}
+	ctx, cancel := ap.bargeInContext()
+	defer cancel()
 
-func (ap *AudioProcessor) playAudioFile(filename string) error {
-	var cmd string
-	switch os := runtime.GOOS; os {
-	case "darwin":
-		cmd = "afplay"
-	case "linux":
-		cmd = "aplay"
-	case "windows":
-		cmd = "start"
-	default:
-		return fmt.Errorf("unsupported operating system")
-	}
-
-	command := exec.Command(cmd, filename)
-	return command.Run()
+	return ap.player.Play(ctx, audioStream, format)
 }
 
 func (ap *AudioProcessor) cleanup() {
@@ -577,8 +612,20 @@ func main() {
 		return
 	}
 
+	fmt.Println("Initializing providers...")
+	llm, err := NewChatModel(config.LLMProvider, config)
+	if err != nil {
+		fmt.Printf("Failed to initialize LLM provider: %v\n", err)
+		return
+	}
+	tts, err := NewSpeechSynthesizer(config.TTSProvider, config)
+	if err != nil {
+		fmt.Printf("Failed to initialize TTS provider: %v\n", err)
+		return
+	}
+
 	fmt.Println("Initializing audio processor...")
-	processor, err := NewAudioProcessor(config)
+	processor, err := NewAudioProcessor(config, llm, tts)
 	if err != nil {
 		fmt.Printf("Failed to initialize audio processor: %v\n", err)
 		return
@@ -609,42 +656,26 @@ func main() {
 			continue
 		}
 
-		// Stop and close the stream after recording
-		if processor.stream != nil {
-			processor.stream.Stop()
-			processor.stream.Close()
-			processor.stream = nil
-		}
-
-		fmt.Println("Saving audio file...")
-		err = processor.saveWAV("voice_record.wav")
-		if err != nil {
-			fmt.Printf("Failed to save WAV file: %v\n", err)
-			continue
-		}
+		// The mic stream is deliberately left open here (startRecording
+		// closes the previous one before opening the next) so audioCallback
+		// keeps running through transcription and Kate's reply, which is
+		// what lets the VAD notice a barge-in while she's speaking.
 
 		fmt.Println("Transcribing audio...")
-		userText, err := processor.transcribeAudio("voice_record.wav")
+		userText, err := processor.transcriber.Stop(context.Background())
 		if err != nil {
 			fmt.Printf("Failed to transcribe audio: %v\n", err)
 			continue
 		}
 		fmt.Printf("You said: %s\n", userText)
 
-		fmt.Println("Sophia is thinking...")
-		response, err, shouldExit := processor.generateResponse(userText)
+		fmt.Println("Sophia is thinking (and speaking as she goes)...")
+		_, err, shouldExit := processor.generateResponse(userText)
 		if err != nil {
 			fmt.Printf("Failed to generate response: %v\n", err)
 			continue
 		}
 
-		fmt.Println("\nSophia is speaking...")
-		err = processor.synthesizeSpeech(response, config)
-		if err != nil {
-			fmt.Printf("Failed to synthesize speech: %v\n", err)
-			continue
-		}
-
 		// Check if we should exit after Sophia's response
 		if shouldExit {
 			fmt.Println("\nGoodbye! Conversation ended.")
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gordonklaus/portaudio"
+	"github.com/hajimehoshi/go-mp3"
+)
+
+// playerChannels is the channel count go-mp3 always decodes to (interleaved
+// stereo 16-bit PCM), regardless of the source MP3's own channel count.
+const playerChannels = 2
+
+// pcmDecoder is anything that yields raw 16-bit little-endian PCM samples,
+// interleaved across Channels(), so Player's output callback doesn't need to
+// know which SpeechSynthesizer produced them.
+type pcmDecoder interface {
+	io.Reader
+	SampleRate() int
+	Channels() int
+}
+
+// mp3PCMDecoder adapts go-mp3's Decoder (which always decodes to stereo) to
+// pcmDecoder.
+type mp3PCMDecoder struct {
+	*mp3.Decoder
+}
+
+func (mp3PCMDecoder) Channels() int { return playerChannels }
+
+// newPCMDecoder wraps stream in the pcmDecoder matching format.
+func newPCMDecoder(stream io.Reader, format AudioFormat) (pcmDecoder, error) {
+	switch format {
+	case AudioFormatWAV:
+		decoder, err := newWAVPCMDecoder(stream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode wav stream: %v", err)
+		}
+		return decoder, nil
+	default:
+		decoder, err := mp3.NewDecoder(stream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode mp3 stream: %v", err)
+		}
+		return mp3PCMDecoder{decoder}, nil
+	}
+}
+
+// wavPCMDecoder reads raw PCM16 samples out of a streaming (non-seekable)
+// WAV source, such as a subprocess stdout pipe. That rules out
+// github.com/go-audio/wav, whose decoder requires io.ReadSeeker, so this
+// hand-rolls just enough RIFF chunk parsing to find "fmt " (for channel
+// count/sample rate) and "data" (where raw samples begin), then defers to
+// the underlying reader for the actual sample bytes.
+type wavPCMDecoder struct {
+	r          io.Reader
+	sampleRate int
+	channels   int
+}
+
+func newWAVPCMDecoder(r io.Reader) (*wavPCMDecoder, error) {
+	var riffHeader [12]byte
+	if _, err := io.ReadFull(r, riffHeader[:]); err != nil {
+		return nil, fmt.Errorf("failed to read riff header: %v", err)
+	}
+	if string(riffHeader[0:4]) != "RIFF" || string(riffHeader[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a wav stream")
+	}
+
+	d := &wavPCMDecoder{r: r}
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(r, chunkHeader[:]); err != nil {
+			return nil, fmt.Errorf("failed to read chunk header: %v", err)
+		}
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := binary.LittleEndian.Uint32(chunkHeader[4:8])
+
+		if chunkID == "data" {
+			// The remainder of the stream is raw samples; leave it for Read.
+			break
+		}
+
+		body := make([]byte, chunkSize)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, fmt.Errorf("failed to read %q chunk: %v", chunkID, err)
+		}
+		if chunkSize%2 == 1 {
+			// Chunks are padded to an even length.
+			var pad [1]byte
+			if _, err := io.ReadFull(r, pad[:]); err != nil {
+				return nil, fmt.Errorf("failed to read chunk padding: %v", err)
+			}
+		}
+
+		if chunkID == "fmt " {
+			if len(body) < 16 {
+				return nil, fmt.Errorf("fmt chunk too short")
+			}
+			d.channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			d.sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+		}
+	}
+
+	if d.channels == 0 || d.sampleRate == 0 {
+		return nil, fmt.Errorf("missing fmt chunk before data")
+	}
+	return d, nil
+}
+
+func (d *wavPCMDecoder) Read(p []byte) (int, error) { return d.r.Read(p) }
+func (d *wavPCMDecoder) SampleRate() int            { return d.sampleRate }
+func (d *wavPCMDecoder) Channels() int              { return d.channels }
+
+// Player decodes a synthesizer's audio stream and plays it directly through
+// a PortAudio output stream, in-process rather than shelling out to
+// afplay/aplay/start. That makes playback sample-accurate and interruptible:
+// Play returns as soon as ctx is cancelled, which is how the VAD's barge-in
+// cancel aborts mid-utterance.
+type Player struct {
+	stream *portaudio.Stream
+	done   chan struct{}
+
+	mu     sync.Mutex
+	paused bool
+}
+
+// NewPlayer creates an idle Player.
+func NewPlayer() *Player {
+	return &Player{}
+}
+
+// Play decodes audioStream (per format) and plays it until it finishes or
+// ctx is cancelled. It closes audioStream before returning.
+func (p *Player) Play(ctx context.Context, audioStream io.ReadCloser, format AudioFormat) error {
+	defer audioStream.Close()
+
+	decoder, err := newPCMDecoder(audioStream, format)
+	if err != nil {
+		return err
+	}
+
+	finished := make(chan struct{})
+	closeFinished := func() {
+		select {
+		case <-finished:
+		default:
+			close(finished)
+		}
+	}
+
+	p.mu.Lock()
+	p.paused = false
+	p.mu.Unlock()
+
+	raw := make([]byte, 0)
+	callback := func(out []int16) {
+		if p.isPaused() {
+			for i := range out {
+				out[i] = 0
+			}
+			return
+		}
+
+		need := len(out) * 2
+		if cap(raw) < need {
+			raw = make([]byte, need)
+		}
+		raw = raw[:need]
+
+		n, err := io.ReadFull(decoder, raw)
+		samples := n / 2
+		for i := 0; i < samples; i++ {
+			out[i] = int16(binary.LittleEndian.Uint16(raw[i*2:]))
+		}
+		for i := samples; i < len(out); i++ {
+			out[i] = 0
+		}
+		if err != nil {
+			closeFinished()
+		}
+	}
+
+	stream, err := portaudio.OpenDefaultStream(0, decoder.Channels(), float64(decoder.SampleRate()), portaudio.FramesPerBufferUnspecified, callback)
+	if err != nil {
+		return fmt.Errorf("failed to open output stream: %v", err)
+	}
+	p.mu.Lock()
+	p.stream = stream
+	p.mu.Unlock()
+	p.done = make(chan struct{})
+	defer func() {
+		stream.Stop()
+		stream.Close()
+		p.mu.Lock()
+		p.stream = nil
+		p.mu.Unlock()
+		close(p.done)
+	}()
+
+	if err := stream.Start(); err != nil {
+		return fmt.Errorf("failed to start output stream: %v", err)
+	}
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// Stop aborts in-flight playback immediately, e.g. on barge-in.
+func (p *Player) Stop() {
+	p.mu.Lock()
+	stream := p.stream
+	p.mu.Unlock()
+	if stream != nil {
+		stream.Abort()
+	}
+}
+
+// Pause silences the output stream without closing it, so Resume can
+// continue the same clause from where it left off.
+func (p *Player) Pause() {
+	p.mu.Lock()
+	p.paused = true
+	p.mu.Unlock()
+}
+
+// Resume un-silences a paused output stream.
+func (p *Player) Resume() {
+	p.mu.Lock()
+	p.paused = false
+	p.mu.Unlock()
+}
+
+func (p *Player) isPaused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// Done returns a channel that closes once the current/most recent Play call
+// returns.
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
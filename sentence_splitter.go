@@ -0,0 +1,26 @@
+package main
+
+import "strings"
+
+// minClauseLength is the shortest a clause may be before it's dispatched to
+// TTS on its own; shorter fragments are held back and merged with whatever
+// follows so playback doesn't come out choppy.
+const minClauseLength = 40
+
+// splitClauses extracts complete clauses from buffer, splitting after each
+// '.', '!', '?' or ';' whose preceding text is at least minClauseLength
+// long, and returns the trailing incomplete text as remainder.
+func splitClauses(buffer string) (clauses []string, remainder string) {
+	start := 0
+	for i, r := range buffer {
+		switch r {
+		case '.', '!', '?', ';':
+			if i+1-start < minClauseLength {
+				continue
+			}
+			clauses = append(clauses, strings.TrimSpace(buffer[start:i+1]))
+			start = i + 1
+		}
+	}
+	return clauses, buffer[start:]
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Tool is a function the model can call mid-conversation. Parameters follows
+// the same JSON-schema shape as FunctionSpec; Handler receives the raw
+// argument JSON the model produced and returns the text fed back to the
+// model as the tool's result.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{}
+	Handler     func(ctx context.Context, args json.RawMessage) (string, error)
+}
+
+// RegisterTool adds t to ap's tool registry, making it callable by the model
+// and included in the function specs sent with every StreamChat call.
+func (ap *AudioProcessor) RegisterTool(t Tool) {
+	if ap.tools == nil {
+		ap.tools = make(map[string]Tool)
+	}
+	ap.tools[t.Name] = t
+}
+
+// toolFunctionSpecs returns the FunctionSpecs for every registered tool, in
+// the shape StreamChat expects.
+func (ap *AudioProcessor) toolFunctionSpecs() []FunctionSpec {
+	specs := make([]FunctionSpec, 0, len(ap.tools))
+	for _, t := range ap.tools {
+		specs = append(specs, FunctionSpec{
+			Name:        t.Name,
+			Description: t.Description,
+			Parameters:  t.Parameters,
+		})
+	}
+	return specs
+}
+
+// callTool dispatches a model-requested function call to its registered
+// handler. It only returns an error for an unknown tool name; a handler's
+// own error is surfaced as the tool result text so the model can see it and
+// react (e.g. apologize or try a different approach) instead of the whole
+// turn failing.
+func (ap *AudioProcessor) callTool(ctx context.Context, name string, args json.RawMessage) (string, error) {
+	tool, ok := ap.tools[name]
+	if !ok {
+		return "", fmt.Errorf("unknown tool %q", name)
+	}
+
+	result, err := tool.Handler(ctx, args)
+	if err != nil {
+		return fmt.Sprintf("tool failed: %v", err), nil
+	}
+	return result, nil
+}
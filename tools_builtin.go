@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// goodbyePhrases are checked against the model's own reply text as a
+// fallback for providers that can't be sent the detectGoodbye tool (see
+// ChatModel.SupportsTools).
+var goodbyePhrases = []string{
+	"goodbye", "good bye", "bye for now", "bye!", "bye.", "see you later",
+	"see you soon", "talk to you later", "talk soon", "have a good night",
+	"until next time",
+}
+
+// looksLikeGoodbye reports whether text reads like the assistant is ending
+// the conversation, for providers where the detectGoodbye tool can't run.
+func looksLikeGoodbye(text string) bool {
+	lower := strings.ToLower(text)
+	for _, phrase := range goodbyePhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerBuiltinTools wires up the tools shipped with the assistant itself.
+func (ap *AudioProcessor) registerBuiltinTools() {
+	ap.RegisterTool(Tool{
+		Name:        "detectGoodbye",
+		Description: "Detect if the user wants to end the conversation",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"shouldExit": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Set to true if the user's message indicates they want to end the conversation",
+				},
+			},
+			"required": []string{"shouldExit"},
+		},
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var parsed struct {
+				ShouldExit bool `json:"shouldExit"`
+			}
+			if err := json.Unmarshal(args, &parsed); err != nil {
+				return "", fmt.Errorf("invalid detectGoodbye arguments: %v", err)
+			}
+			ap.pendingExit = parsed.ShouldExit
+			return "acknowledged", nil
+		},
+	})
+
+	ap.RegisterTool(Tool{
+		Name:        "get_time",
+		Description: "Get the current local date and time",
+		Parameters: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			return time.Now().Format("Monday, January 2, 2006 at 3:04 PM"), nil
+		},
+	})
+
+	ap.RegisterTool(Tool{
+		Name:        "set_timer",
+		Description: "Set a timer that announces itself once the given number of seconds has elapsed",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"seconds": map[string]interface{}{
+					"type":        "number",
+					"description": "How many seconds from now the timer should fire",
+				},
+				"label": map[string]interface{}{
+					"type":        "string",
+					"description": "What to call the timer when it fires",
+				},
+			},
+			"required": []string{"seconds"},
+		},
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var parsed struct {
+				Seconds float64 `json:"seconds"`
+				Label   string  `json:"label"`
+			}
+			if err := json.Unmarshal(args, &parsed); err != nil {
+				return "", fmt.Errorf("invalid set_timer arguments: %v", err)
+			}
+			if parsed.Seconds <= 0 {
+				return "", fmt.Errorf("seconds must be positive")
+			}
+
+			label := parsed.Label
+			if label == "" {
+				label = "timer"
+			}
+
+			duration := time.Duration(parsed.Seconds * float64(time.Second))
+			time.AfterFunc(duration, func() {
+				fmt.Printf("\n[%s is done!]\n", label)
+			})
+
+			return fmt.Sprintf("started a %s for %.0f seconds", label, parsed.Seconds), nil
+		},
+	})
+
+	ap.RegisterTool(Tool{
+		Name:        "control_playback",
+		Description: "Pause or resume playback of the assistant's own voice",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"action": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"pause", "resume"},
+					"description": "Whether to pause or resume playback",
+				},
+			},
+			"required": []string{"action"},
+		},
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var parsed struct {
+				Action string `json:"action"`
+			}
+			if err := json.Unmarshal(args, &parsed); err != nil {
+				return "", fmt.Errorf("invalid control_playback arguments: %v", err)
+			}
+
+			switch parsed.Action {
+			case "pause":
+				ap.player.Pause()
+				return "playback paused", nil
+			case "resume":
+				ap.player.Resume()
+				return "playback resumed", nil
+			default:
+				return "", fmt.Errorf("unknown action %q", parsed.Action)
+			}
+		},
+	})
+
+	ap.RegisterTool(Tool{
+		Name:        "search_web",
+		Description: "Search the web for up-to-date information",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "What to search for",
+				},
+			},
+			"required": []string{"query"},
+		},
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var parsed struct {
+				Query string `json:"query"`
+			}
+			if err := json.Unmarshal(args, &parsed); err != nil {
+				return "", fmt.Errorf("invalid search_web arguments: %v", err)
+			}
+			return ap.webSearcher.Search(ctx, parsed.Query)
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// PartialTranscript is one incremental result from a Transcriber. Final
+// results have IsFinal set and are not followed by further partials for the
+// same turn.
+type PartialTranscript struct {
+	Text    string
+	IsFinal bool
+}
+
+// Transcriber turns a live stream of PCM frames into text. Implementations
+// may stream interim results as audio arrives (openai_realtime,
+// google_streaming) or only emit a single final result once the turn ends
+// (whisper). Callers feed frames as they arrive from PortAudio instead of
+// buffering the whole utterance first.
+type Transcriber interface {
+	// Start begins a new transcription session for one user turn.
+	Start(ctx context.Context) error
+	// WriteFrame feeds one PCM16 mono frame, sampled at the processor's
+	// configured SampleRate, into the in-flight session.
+	WriteFrame(frame []int16) error
+	// Partials delivers interim and final transcripts for the current
+	// session as they become available.
+	Partials() <-chan PartialTranscript
+	// Stop ends the session (flushing any buffered audio) and returns the
+	// final transcript, closing the underlying connection/stream.
+	Stop(ctx context.Context) (string, error)
+}
+
+// NewTranscriber builds the Transcriber selected by the STT_PROVIDER env var
+// (whisper|openai_realtime|google_streaming|whisper_cpp), defaulting to
+// whisper.
+func NewTranscriber(name string, ap *AudioProcessor) (Transcriber, error) {
+	switch name {
+	case "", "whisper":
+		return newWhisperTranscriber(ap), nil
+	case "openai_realtime":
+		return newOpenAIRealtimeTranscriber(ap)
+	case "google_streaming":
+		return newGoogleStreamingTranscriber(ap)
+	case "whisper_cpp":
+		return newWhisperCppTranscriber(ap), nil
+	default:
+		return nil, fmt.Errorf("unknown STT_PROVIDER %q", name)
+	}
+}
+
+// whisperTranscriber buffers frames for the whole turn and transcribes them
+// in one batch request when the turn ends, matching the existing
+// record-then-transcribe behavior but through the Transcriber interface.
+type whisperTranscriber struct {
+	client   *openai.Client
+	config   *Config
+	frames   [][]int16
+	partials chan PartialTranscript
+}
+
+func newWhisperTranscriber(ap *AudioProcessor) *whisperTranscriber {
+	return &whisperTranscriber{
+		client:   openai.NewClient(ap.config.OpenAIKey),
+		config:   ap.config,
+		partials: make(chan PartialTranscript, 1),
+	}
+}
+
+func (w *whisperTranscriber) Start(ctx context.Context) error {
+	w.frames = w.frames[:0]
+	return nil
+}
+
+func (w *whisperTranscriber) WriteFrame(frame []int16) error {
+	w.frames = append(w.frames, frame)
+	return nil
+}
+
+func (w *whisperTranscriber) Partials() <-chan PartialTranscript {
+	return w.partials
+}
+
+func (w *whisperTranscriber) Stop(ctx context.Context) (string, error) {
+	const filename = "voice_record.wav"
+	if err := writeFramesToWAV(w.frames, w.config, filename); err != nil {
+		return "", fmt.Errorf("failed to save WAV file: %v", err)
+	}
+
+	text, err := transcribeWithWhisper(ctx, w.client, filename)
+	if err != nil {
+		return "", err
+	}
+
+	w.partials <- PartialTranscript{Text: text, IsFinal: true}
+	return text, nil
+}
+
+// transcribeWithWhisper uploads filename to the OpenAI Whisper API and
+// returns the transcript.
+func transcribeWithWhisper(ctx context.Context, client *openai.Client, filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to open audio file: %v", err)
+	}
+	defer file.Close()
+
+	resp, err := client.CreateTranscription(ctx, openai.AudioRequest{
+		Model:    openai.Whisper1,
+		Reader:   file,
+		FilePath: filename,
+		Language: "en",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to transcribe audio: %v", err)
+	}
+
+	return resp.Text, nil
+}
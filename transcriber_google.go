@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	speech "cloud.google.com/go/speech/apiv1"
+	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
+)
+
+// googleStreamingTranscriber streams PCM16 audio to Google Cloud Speech's
+// StreamingRecognize RPC and surfaces the interim/final results it returns.
+type googleStreamingTranscriber struct {
+	client     *speech.Client
+	stream     speechpb.Speech_StreamingRecognizeClient
+	cancel     context.CancelFunc
+	sampleRate int
+
+	partials chan PartialTranscript
+	final    chan googleFinalResult
+}
+
+// googleFinalResult is what readLoop hands Stop once the utterance is done,
+// one way or another: either the final transcript, or the error that ended
+// the stream before one arrived.
+type googleFinalResult struct {
+	text string
+	err  error
+}
+
+func newGoogleStreamingTranscriber(ap *AudioProcessor) (*googleStreamingTranscriber, error) {
+	client, err := speech.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Google Speech client: %v", err)
+	}
+
+	return &googleStreamingTranscriber{
+		client:     client,
+		sampleRate: ap.config.SampleRate,
+		partials:   make(chan PartialTranscript, 8),
+		final:      make(chan googleFinalResult, 1),
+	}, nil
+}
+
+func (g *googleStreamingTranscriber) Start(ctx context.Context) error {
+	// Recreate per-turn channels rather than reusing the ones from a prior
+	// turn: the previous readLoop goroutine keeps running until its stream is
+	// actually torn down by Stop's g.cancel(), so it can still write a stale
+	// result/error into an old final after Stop has already read from it.
+	g.partials = make(chan PartialTranscript, 8)
+	g.final = make(chan googleFinalResult, 1)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	g.cancel = cancel
+
+	stream, err := g.client.StreamingRecognize(streamCtx)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to open streaming recognize: %v", err)
+	}
+	g.stream = stream
+
+	config := &speechpb.StreamingRecognizeRequest{
+		StreamingRequest: &speechpb.StreamingRecognizeRequest_StreamingConfig{
+			StreamingConfig: &speechpb.StreamingRecognitionConfig{
+				Config: &speechpb.RecognitionConfig{
+					Encoding:        speechpb.RecognitionConfig_LINEAR16,
+					SampleRateHertz: int32(g.sampleRate),
+					LanguageCode:    "en-US",
+				},
+				InterimResults: true,
+			},
+		},
+	}
+	if err := g.stream.Send(config); err != nil {
+		return fmt.Errorf("failed to send streaming config: %v", err)
+	}
+
+	go g.readLoop()
+	return nil
+}
+
+func (g *googleStreamingTranscriber) readLoop() {
+	for {
+		resp, err := g.stream.Recv()
+		if err == io.EOF {
+			close(g.partials)
+			g.final <- googleFinalResult{err: fmt.Errorf("streaming recognize ended before a final result")}
+			return
+		}
+		if err != nil {
+			close(g.partials)
+			g.final <- googleFinalResult{err: fmt.Errorf("streaming recognize read error: %v", err)}
+			return
+		}
+
+		for _, result := range resp.Results {
+			if len(result.Alternatives) == 0 {
+				continue
+			}
+			text := result.Alternatives[0].Transcript
+			g.emitPartial(PartialTranscript{Text: text, IsFinal: result.IsFinal})
+			if result.IsFinal {
+				g.final <- googleFinalResult{text: text}
+			}
+		}
+	}
+}
+
+// emitPartial delivers p to Partials() if anything is listening, but never
+// blocks: nothing is required to drain that channel, and stalling here would
+// stop readLoop from ever reaching the final result Stop waits on.
+func (g *googleStreamingTranscriber) emitPartial(p PartialTranscript) {
+	select {
+	case g.partials <- p:
+	default:
+	}
+}
+
+func (g *googleStreamingTranscriber) WriteFrame(frame []int16) error {
+	raw := make([]byte, len(frame)*2)
+	for i, sample := range frame {
+		binary.LittleEndian.PutUint16(raw[i*2:], uint16(sample))
+	}
+
+	return g.stream.Send(&speechpb.StreamingRecognizeRequest{
+		StreamingRequest: &speechpb.StreamingRecognizeRequest_AudioContent{
+			AudioContent: raw,
+		},
+	})
+}
+
+func (g *googleStreamingTranscriber) Partials() <-chan PartialTranscript {
+	return g.partials
+}
+
+func (g *googleStreamingTranscriber) Stop(ctx context.Context) (string, error) {
+	if err := g.stream.CloseSend(); err != nil {
+		return "", fmt.Errorf("failed to close streaming recognize: %v", err)
+	}
+
+	select {
+	case result := <-g.final:
+		g.cancel()
+		return result.text, result.err
+	case <-ctx.Done():
+		g.cancel()
+		return "", ctx.Err()
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+const openAIRealtimeURL = "wss://api.openai.com/v1/realtime?intent=transcription"
+
+// realtimeEvent is the minimal shape of the Realtime API events we care
+// about; the wire protocol carries many more event types we simply ignore.
+type realtimeEvent struct {
+	Type       string `json:"type"`
+	Delta      string `json:"delta"`
+	Transcript string `json:"transcript"`
+	Audio      string `json:"audio,omitempty"`
+}
+
+// realtimeFinalResult is what readLoop hands Stop once the utterance is
+// done, one way or another: either the completed transcript, or the error
+// that ended the stream before one arrived.
+type realtimeFinalResult struct {
+	text string
+	err  error
+}
+
+// openAIRealtimeTranscriber streams PCM16 audio to the OpenAI Realtime
+// transcription endpoint over WebSocket and surfaces the interim/final
+// transcripts it streams back.
+type openAIRealtimeTranscriber struct {
+	apiKey   string
+	conn     *websocket.Conn
+	partials chan PartialTranscript
+	final    chan realtimeFinalResult
+}
+
+func newOpenAIRealtimeTranscriber(ap *AudioProcessor) (*openAIRealtimeTranscriber, error) {
+	if ap.config.OpenAIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY is required for openai_realtime transcriber")
+	}
+	return &openAIRealtimeTranscriber{
+		apiKey:   ap.config.OpenAIKey,
+		partials: make(chan PartialTranscript, 8),
+		final:    make(chan realtimeFinalResult, 1),
+	}, nil
+}
+
+func (o *openAIRealtimeTranscriber) Start(ctx context.Context) error {
+	// Recreate per-turn channels rather than reusing the ones from a prior
+	// turn: the previous readLoop goroutine keeps running until its
+	// connection is actually closed by Stop, so it can still write a stale
+	// read error into an old final after Stop has already read from it.
+	o.partials = make(chan PartialTranscript, 8)
+	o.final = make(chan realtimeFinalResult, 1)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+o.apiKey)
+	header.Set("OpenAI-Beta", "realtime=v1")
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, openAIRealtimeURL, header)
+	if err != nil {
+		return fmt.Errorf("failed to dial realtime endpoint: %v", err)
+	}
+	o.conn = conn
+
+	sessionUpdate := map[string]any{
+		"type": "session.update",
+		"session": map[string]any{
+			"input_audio_format":        "pcm16",
+			"input_audio_transcription": map[string]any{"model": "gpt-4o-transcribe"},
+		},
+	}
+	if err := o.conn.WriteJSON(sessionUpdate); err != nil {
+		return fmt.Errorf("failed to configure realtime session: %v", err)
+	}
+
+	go o.readLoop()
+	return nil
+}
+
+func (o *openAIRealtimeTranscriber) readLoop() {
+	for {
+		var evt realtimeEvent
+		if err := o.conn.ReadJSON(&evt); err != nil {
+			close(o.partials)
+			o.final <- realtimeFinalResult{err: fmt.Errorf("realtime websocket read error: %v", err)}
+			return
+		}
+
+		switch evt.Type {
+		case "conversation.item.input_audio_transcription.delta":
+			o.emitPartial(PartialTranscript{Text: evt.Delta, IsFinal: false})
+		case "conversation.item.input_audio_transcription.completed":
+			o.emitPartial(PartialTranscript{Text: evt.Transcript, IsFinal: true})
+			o.final <- realtimeFinalResult{text: evt.Transcript}
+		}
+	}
+}
+
+// emitPartial delivers p to Partials() if anything is listening, but never
+// blocks: nothing is required to drain that channel, and stalling here would
+// stop readLoop from ever reaching the "completed" event Stop waits on.
+func (o *openAIRealtimeTranscriber) emitPartial(p PartialTranscript) {
+	select {
+	case o.partials <- p:
+	default:
+	}
+}
+
+func (o *openAIRealtimeTranscriber) WriteFrame(frame []int16) error {
+	raw := make([]byte, len(frame)*2)
+	for i, sample := range frame {
+		binary.LittleEndian.PutUint16(raw[i*2:], uint16(sample))
+	}
+
+	return o.conn.WriteJSON(map[string]any{
+		"type":  "input_audio_buffer.append",
+		"audio": base64.StdEncoding.EncodeToString(raw),
+	})
+}
+
+func (o *openAIRealtimeTranscriber) Partials() <-chan PartialTranscript {
+	return o.partials
+}
+
+func (o *openAIRealtimeTranscriber) Stop(ctx context.Context) (string, error) {
+	if err := o.conn.WriteJSON(map[string]any{"type": "input_audio_buffer.commit"}); err != nil {
+		return "", fmt.Errorf("failed to commit audio buffer: %v", err)
+	}
+
+	select {
+	case result := <-o.final:
+		o.conn.Close()
+		return result.text, result.err
+	case <-ctx.Done():
+		o.conn.Close()
+		return "", ctx.Err()
+	}
+}
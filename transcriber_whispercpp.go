@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// whisperCppTranscriber batches a turn's frames into a WAV file and
+// transcribes it with a local whisper.cpp binary, for fully offline STT.
+type whisperCppTranscriber struct {
+	ap       *AudioProcessor
+	binary   string
+	model    string
+	frames   [][]int16
+	partials chan PartialTranscript
+}
+
+func newWhisperCppTranscriber(ap *AudioProcessor) *whisperCppTranscriber {
+	return &whisperCppTranscriber{
+		ap:       ap,
+		binary:   getEnvWithDefault("WHISPER_CPP_BINARY", "whisper-cpp"),
+		model:    getEnvWithDefault("WHISPER_CPP_MODEL", ""),
+		partials: make(chan PartialTranscript, 1),
+	}
+}
+
+func (w *whisperCppTranscriber) Start(ctx context.Context) error {
+	w.frames = w.frames[:0]
+	return nil
+}
+
+func (w *whisperCppTranscriber) WriteFrame(frame []int16) error {
+	w.frames = append(w.frames, frame)
+	return nil
+}
+
+func (w *whisperCppTranscriber) Partials() <-chan PartialTranscript {
+	return w.partials
+}
+
+func (w *whisperCppTranscriber) Stop(ctx context.Context) (string, error) {
+	const filename = "voice_record.wav"
+	if err := writeFramesToWAV(w.frames, w.ap.config, filename); err != nil {
+		return "", fmt.Errorf("failed to save WAV file: %v", err)
+	}
+
+	args := []string{"-f", filename, "--no-timestamps"}
+	if w.model != "" {
+		args = append(args, "-m", w.model)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, w.binary, args...)
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run whisper.cpp: %v", err)
+	}
+
+	text := strings.TrimSpace(stdout.String())
+	w.partials <- PartialTranscript{Text: text, IsFinal: true}
+	return text, nil
+}
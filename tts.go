@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// AudioFormat identifies how a SpeechSynthesizer encoded its output stream,
+// so Player knows which decoder to use.
+type AudioFormat int
+
+const (
+	AudioFormatMP3 AudioFormat = iota
+	AudioFormatWAV
+)
+
+// SpeechSynthesizer turns text into audio. Synthesize streams the encoded
+// audio (MP3 for ElevenLabs/OpenAI, WAV for local Piper/Coqui) as it's
+// produced so callers can start playback before synthesis finishes.
+type SpeechSynthesizer interface {
+	Synthesize(ctx context.Context, text string) (io.ReadCloser, AudioFormat, error)
+}
+
+// NewSpeechSynthesizer builds the SpeechSynthesizer selected by the
+// TTS_PROVIDER env var (elevenlabs|openai|local_piper), defaulting to
+// elevenlabs.
+func NewSpeechSynthesizer(name string, config *Config) (SpeechSynthesizer, error) {
+	switch name {
+	case "", "elevenlabs":
+		if config.ElevenLabsKey == "" {
+			return nil, fmt.Errorf("ELEVENLABS_API_KEY is required for the elevenlabs TTS provider")
+		}
+		return newElevenLabsSynthesizer(config), nil
+	case "openai":
+		if config.OpenAIKey == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY is required for the openai TTS provider")
+		}
+		return newOpenAISynthesizer(config), nil
+	case "local_piper":
+		return newPiperSynthesizer(config), nil
+	default:
+		return nil, fmt.Errorf("unknown TTS_PROVIDER %q", name)
+	}
+}
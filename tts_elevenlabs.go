@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/haguro/elevenlabs-go"
+)
+
+// elevenLabsSynthesizer wraps the existing ElevenLabs integration behind the
+// SpeechSynthesizer interface.
+type elevenLabsSynthesizer struct {
+	client  *elevenlabs.Client
+	voiceID string
+	modelID string
+}
+
+func newElevenLabsSynthesizer(config *Config) *elevenLabsSynthesizer {
+	return &elevenLabsSynthesizer{
+		client:  elevenlabs.NewClient(context.Background(), config.ElevenLabsKey, 30*time.Second),
+		voiceID: config.ElevenLabsVoiceID,
+		modelID: config.ElevenLabsModelID,
+	}
+}
+
+func (e *elevenLabsSynthesizer) resolveVoiceID() (string, error) {
+	if e.voiceID != "" {
+		return e.voiceID, nil
+	}
+
+	voices, err := e.client.GetVoices()
+	if err != nil {
+		return "", fmt.Errorf("failed to get voices: %v", err)
+	}
+
+	for _, voice := range voices {
+		if voice.Name == "Nicole" {
+			return voice.VoiceId, nil
+		}
+	}
+	if len(voices) > 0 {
+		return voices[0].VoiceId, nil
+	}
+	return "", fmt.Errorf("no voices available")
+}
+
+func (e *elevenLabsSynthesizer) Synthesize(ctx context.Context, text string) (io.ReadCloser, AudioFormat, error) {
+	voiceID, err := e.resolveVoiceID()
+	if err != nil {
+		return nil, AudioFormatMP3, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := e.client.TextToSpeechStream(pw, voiceID, elevenlabs.TextToSpeechRequest{
+			Text:    text,
+			ModelID: e.modelID,
+		})
+		pw.CloseWithError(err)
+	}()
+
+	return pr, AudioFormatMP3, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// openAISynthesizer uses OpenAI's tts-1 model as an ElevenLabs alternative.
+type openAISynthesizer struct {
+	client *openai.Client
+	voice  openai.SpeechVoice
+}
+
+func newOpenAISynthesizer(config *Config) *openAISynthesizer {
+	return &openAISynthesizer{
+		client: openai.NewClient(config.OpenAIKey),
+		voice:  openai.VoiceNova,
+	}
+}
+
+func (o *openAISynthesizer) Synthesize(ctx context.Context, text string) (io.ReadCloser, AudioFormat, error) {
+	stream, err := o.client.CreateSpeech(ctx, openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          text,
+		Voice:          o.voice,
+		ResponseFormat: openai.SpeechResponseFormatMp3,
+	})
+	return stream, AudioFormatMP3, err
+}
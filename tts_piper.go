@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// piperSynthesizer shells out to a local Piper (or Coqui, via the same CLI
+// convention) binary for fully offline speech synthesis. The binary reads
+// text on stdin and writes a WAV file to stdout.
+type piperSynthesizer struct {
+	binary string
+	model  string
+}
+
+func newPiperSynthesizer(config *Config) *piperSynthesizer {
+	return &piperSynthesizer{
+		binary: getEnvWithDefault("PIPER_BINARY", "piper"),
+		model:  getEnvWithDefault("PIPER_MODEL", ""),
+	}
+}
+
+// pipeReadCloser closes the underlying stdout pipe and then waits for the
+// process to exit, surfacing any non-zero exit as an error on Close.
+type pipeReadCloser struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (p *pipeReadCloser) Close() error {
+	_ = p.ReadCloser.Close()
+	return p.cmd.Wait()
+}
+
+func (p *piperSynthesizer) Synthesize(ctx context.Context, text string) (io.ReadCloser, AudioFormat, error) {
+	// "-" tells piper to write its WAV output to stdout instead of a file.
+	args := []string{"--output_file", "-"}
+	if p.model != "" {
+		args = append(args, "--model", p.model)
+	}
+
+	cmd := exec.CommandContext(ctx, p.binary, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, AudioFormatWAV, fmt.Errorf("failed to open piper stdin: %v", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, AudioFormatWAV, fmt.Errorf("failed to open piper stdout: %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, AudioFormatWAV, fmt.Errorf("failed to start piper: %v", err)
+	}
+
+	go func() {
+		defer stdin.Close()
+		io.WriteString(stdin, text)
+	}()
+
+	return &pipeReadCloser{ReadCloser: stdout, cmd: cmd}, AudioFormatWAV, nil
+}
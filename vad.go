@@ -0,0 +1,154 @@
+package main
+
+import "math"
+
+// Tuning constants for the streaming voice activity detector. Frame sizes in
+// the 10-30ms range (160/320/480 samples at 16 kHz) are assumed, but the
+// detector adapts its zero-crossing window to whatever FramesPerBuffer is
+// configured.
+const (
+	vadFastAlpha     = 0.9   // Fast EMA for background energy tracking
+	vadSlowAlpha     = 0.995 // Slow EMA for background energy tracking
+	vadStartMarginDB = 6.0   // dB above slow background energy required to flag speech
+	vadMinZCRPer10ms = 10.0  // Lower bound of plausible voiced zero-crossing rate
+	vadMaxZCRPer10ms = 100.0 // Upper bound of plausible voiced zero-crossing rate
+	vadStartHangover = 3     // Consecutive speech frames required to trigger start
+	vadEndHangover   = 20    // Consecutive non-speech frames required to trigger end
+)
+
+// VoiceDetector is a streaming VAD that classifies fixed-size PCM frames as
+// speech or silence. It combines short-time energy (relative to an adaptive
+// background estimate) with a zero-crossing-rate gate so it doesn't latch
+// onto steady-state noise, and debounces the decision with a hangover
+// counter so brief pauses mid-sentence don't end the segment early.
+//
+// Besides the start/end callbacks, it also supports barge-in: if speech is
+// detected while Kate is mid-playback, Cancel's channel fires so the caller
+// can abort synthesis/playback immediately.
+type VoiceDetector struct {
+	sampleRate int
+	frameSize  int
+
+	fastEnergy float64
+	slowEnergy float64
+
+	speechHangover  int
+	silenceHangover int
+	inSpeech        bool
+
+	OnSpeechStart func()
+	OnSpeechEnd   func()
+
+	cancel chan struct{}
+}
+
+// NewVoiceDetector creates a VoiceDetector for frames of frameSize samples
+// captured at sampleRate Hz.
+func NewVoiceDetector(sampleRate, frameSize int) *VoiceDetector {
+	return &VoiceDetector{
+		sampleRate: sampleRate,
+		frameSize:  frameSize,
+		cancel:     make(chan struct{}, 1),
+	}
+}
+
+// Reset clears adaptation and hangover state, e.g. at the start of a new
+// listening turn.
+func (v *VoiceDetector) Reset() {
+	v.fastEnergy = 0
+	v.slowEnergy = 0
+	v.speechHangover = 0
+	v.silenceHangover = 0
+	v.inSpeech = false
+
+	// Drain a stale barge-in signal left over from the previous turn: if the
+	// watcher selecting on Cancel() exited via its own ctx.Done() at the same
+	// moment triggerCancel() fired, the buffered token is never read, and
+	// would otherwise make the very next turn's watcher see an immediate
+	// "cancel" before anything has even started playing.
+	select {
+	case <-v.cancel:
+	default:
+	}
+}
+
+// Cancel returns a channel that fires once when a barge-in is detected:
+// speech starting while playing is true. Callers should select on it
+// alongside whatever they're waiting on (command completion, HTTP stream,
+// etc.) to abort mid-utterance.
+func (v *VoiceDetector) Cancel() <-chan struct{} {
+	return v.cancel
+}
+
+func (v *VoiceDetector) triggerCancel() {
+	select {
+	case v.cancel <- struct{}{}:
+	default:
+	}
+}
+
+// energyAndZCR computes short-time energy (sum of squares / N) and the
+// number of sign changes in a single frame.
+func energyAndZCR(frame []int16) (float64, int) {
+	var energySum float64
+	crossings := 0
+	for i, sample := range frame {
+		s := float64(sample)
+		energySum += s * s
+		if i > 0 && (frame[i-1] >= 0) != (sample >= 0) {
+			crossings++
+		}
+	}
+	return energySum / float64(len(frame)), crossings
+}
+
+// ProcessFrame classifies a single PCM frame, returning whether the detector
+// is currently within a speech segment. playing should be true while Kate is
+// speaking so that a detected speech onset triggers a barge-in cancel.
+func (v *VoiceDetector) ProcessFrame(frame []int16, playing bool) bool {
+	if len(frame) == 0 {
+		return v.inSpeech
+	}
+
+	energy, crossings := energyAndZCR(frame)
+	v.fastEnergy = v.fastEnergy*vadFastAlpha + energy*(1-vadFastAlpha)
+	v.slowEnergy = v.slowEnergy*vadSlowAlpha + energy*(1-vadSlowAlpha)
+
+	marginLinear := math.Pow(10, vadStartMarginDB/10)
+	msPerFrame := 1000.0 * float64(v.frameSize) / float64(v.sampleRate)
+	zcrPer10ms := float64(crossings) * (10.0 / msPerFrame)
+
+	isVoiced := energy > v.slowEnergy*marginLinear &&
+		zcrPer10ms >= vadMinZCRPer10ms && zcrPer10ms <= vadMaxZCRPer10ms
+
+	switch {
+	case !v.inSpeech && isVoiced:
+		v.speechHangover++
+		v.silenceHangover = 0
+		if v.speechHangover >= vadStartHangover {
+			v.inSpeech = true
+			v.speechHangover = 0
+			if playing {
+				v.triggerCancel()
+			}
+			if v.OnSpeechStart != nil {
+				v.OnSpeechStart()
+			}
+		}
+	case !v.inSpeech && !isVoiced:
+		v.speechHangover = 0
+	case v.inSpeech && isVoiced:
+		v.silenceHangover = 0
+	case v.inSpeech && !isVoiced:
+		v.silenceHangover++
+		if v.silenceHangover >= vadEndHangover {
+			v.inSpeech = false
+			v.silenceHangover = 0
+			if v.OnSpeechEnd != nil {
+				v.OnSpeechEnd()
+			}
+		}
+	}
+
+	return v.inSpeech
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// WebSearcher looks up a query and returns a short text summary of results.
+// It's a narrow interface so the search_web tool can be backed by different
+// engines (or a fake, in tests) without generateResponse knowing the
+// difference.
+type WebSearcher interface {
+	Search(ctx context.Context, query string) (string, error)
+}
+
+// NewWebSearcher returns the WebSearcher selected by the WEB_SEARCH_PROVIDER
+// env var (duckduckgo|none), defaulting to DuckDuckGo's keyless instant
+// answer API so search_web works with no API key configured.
+func NewWebSearcher(name string) WebSearcher {
+	switch name {
+	case "none":
+		return noWebSearcher{}
+	default:
+		return &duckDuckGoSearcher{client: &http.Client{}}
+	}
+}
+
+// noWebSearcher disables search_web outright, e.g. for fully offline setups.
+type noWebSearcher struct{}
+
+func (noWebSearcher) Search(ctx context.Context, query string) (string, error) {
+	return "", fmt.Errorf("web search is disabled (WEB_SEARCH_PROVIDER=none)")
+}
+
+// duckDuckGoSearcher uses DuckDuckGo's keyless Instant Answer API.
+type duckDuckGoSearcher struct {
+	client *http.Client
+}
+
+func (d *duckDuckGoSearcher) Search(ctx context.Context, query string) (string, error) {
+	endpoint := "https://api.duckduckgo.com/?" + url.Values{
+		"q":       {query},
+		"format":  {"json"},
+		"no_html": {"1"},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build search request: %v", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("web search request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read search response: %v", err)
+	}
+
+	var result struct {
+		AbstractText  string `json:"AbstractText"`
+		RelatedTopics []struct {
+			Text string `json:"Text"`
+		} `json:"RelatedTopics"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse search response: %v", err)
+	}
+
+	if result.AbstractText != "" {
+		return result.AbstractText, nil
+	}
+	if len(result.RelatedTopics) > 0 && result.RelatedTopics[0].Text != "" {
+		return result.RelatedTopics[0].Text, nil
+	}
+	return "no results found", nil
+}